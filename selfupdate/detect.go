@@ -7,30 +7,35 @@ import (
 	"strings"
 
 	"github.com/blang/semver"
-	"github.com/google/go-github/v50/github"
 )
 
 var reVersion = regexp.MustCompile(`\d+\.\d+\.\d+`)
 
-func findAssetFromRelease(rel *github.RepositoryRelease,
+func findAssetFromRelease(rel *SourceRelease,
 	suffixes []string, targetVersion string, filters []*regexp.Regexp,
-) (*github.ReleaseAsset, semver.Version, error) {
-	if targetVersion != "" && targetVersion != rel.GetTagName() {
-		return nil, semver.Version{}, fmt.Errorf("%q does not match the target version (%s)", targetVersion, targetVersion)
+	strategy RolloutStrategy, clientKey string,
+) (*SourceAsset, semver.Version, *RolloutInfo, error) {
+	if targetVersion != "" && targetVersion != rel.TagName {
+		return nil, semver.Version{}, nil, fmt.Errorf("%q does not match the target version (%s)", targetVersion, targetVersion)
 	}
 
-	if targetVersion == "" && rel.GetDraft() {
-		return nil, semver.Version{}, fmt.Errorf("target version %q is a draft", rel.GetTagName())
+	if targetVersion == "" && rel.Draft {
+		return nil, semver.Version{}, nil, fmt.Errorf("target version %q is a draft", rel.TagName)
 	}
 
-	if targetVersion == "" && rel.GetPrerelease() {
-		return nil, semver.Version{}, fmt.Errorf("target version %q is a pre-release", rel.GetTagName())
+	if targetVersion == "" && rel.Prerelease {
+		return nil, semver.Version{}, nil, fmt.Errorf("target version %q is a pre-release", rel.TagName)
 	}
 
-	verText := rel.GetTagName()
+	rollout := parseRolloutInfo(rel.Body)
+	if targetVersion == "" && !strategy.Eligible(rollout, clientKey) {
+		return nil, semver.Version{}, rollout, fmt.Errorf("target version %q is not yet rolled out to this install", rel.TagName)
+	}
+
+	verText := rel.TagName
 	indices := reVersion.FindStringIndex(verText)
 	if indices == nil {
-		return nil, semver.Version{}, fmt.Errorf("version %q does not adopt semantic versioning", verText)
+		return nil, semver.Version{}, rollout, fmt.Errorf("version %q does not adopt semantic versioning", verText)
 	}
 	if indices[0] > 0 {
 		log.Println("Strip prefix of version", verText[:indices[0]], "from", verText)
@@ -41,11 +46,12 @@ func findAssetFromRelease(rel *github.RepositoryRelease,
 	// the semantic versioning. So it should be skipped.
 	ver, err := semver.Make(verText)
 	if err != nil {
-		return nil, semver.Version{}, fmt.Errorf("version %q does not adopt semantic versioning: %w", verText, err)
+		return nil, semver.Version{}, rollout, fmt.Errorf("version %q does not adopt semantic versioning: %w", verText, err)
 	}
 
-	for _, asset := range rel.Assets {
-		name := asset.GetName()
+	for i := range rel.Assets {
+		asset := &rel.Assets[i]
+		name := asset.Name
 		if len(filters) > 0 {
 			// if some filters are defined, match them: if any one matches, the asset is selected
 			matched := false
@@ -65,28 +71,30 @@ func findAssetFromRelease(rel *github.RepositoryRelease,
 		for _, s := range suffixes {
 			if strings.HasSuffix(name, s) { // require version, arch etc
 				// default: assume single artifact
-				return asset, ver, nil
+				return asset, ver, rollout, nil
 			}
 		}
 	}
 
-	return nil, semver.Version{}, fmt.Errorf(
-		"no suitable asset was found in release %q", rel.GetTagName())
+	return nil, semver.Version{}, rollout, fmt.Errorf(
+		"no suitable asset was found in release %q", rel.TagName)
 }
 
-func findValidationAsset(rel *github.RepositoryRelease, validationName string) (*github.ReleaseAsset, bool) {
-	for _, asset := range rel.Assets {
-		if asset.GetName() == validationName {
-			return asset, true
+func findValidationAsset(rel *SourceRelease, validationName string) (*SourceAsset, bool) {
+	for i := range rel.Assets {
+		if rel.Assets[i].Name == validationName {
+			return &rel.Assets[i], true
 		}
 	}
 	return nil, false
 }
 
-func findReleaseAndAsset(rels []*github.RepositoryRelease,
+func findReleaseAndAsset(rels []*SourceRelease,
 	targetVersion string,
 	filters []*regexp.Regexp,
-) (*github.RepositoryRelease, *github.ReleaseAsset, semver.Version, error) {
+	strategy RolloutStrategy,
+	clientKey string,
+) (*SourceRelease, *SourceAsset, semver.Version, *RolloutInfo, error) {
 	// Generate candidates
 	suffixes := make([]string, 0, 2*7*2)
 	for _, sep := range []rune{'_', '-'} {
@@ -101,17 +109,22 @@ func findReleaseAndAsset(rels []*github.RepositoryRelease,
 	}
 
 	var ver semver.Version
-	var asset *github.ReleaseAsset
-	var release *github.RepositoryRelease
+	var asset *SourceAsset
+	var release *SourceRelease
+	var rollout *RolloutInfo
 
 	// Find the latest version from the list of releases.
 	// Returned list from GitHub API is in the order of the date when created.
 	//   ref: https://github.com/rhysd/go-github-selfupdate/issues/11
 	for _, rel := range rels {
-		a, v, err := findAssetFromRelease(rel, suffixes, targetVersion, filters)
+		a, v, ro, err := findAssetFromRelease(rel, suffixes, targetVersion, filters, strategy, clientKey)
 		if err != nil {
-			return nil, nil, ver, fmt.Errorf("could not find asset from release %q for %s %s: %w",
-				rel.GetTagName(), runtime.GOOS, runtime.GOARCH, err)
+			// A release being a draft/pre-release, not yet rolled out to this
+			// install, or simply not matching targetVersion doesn't disqualify
+			// the whole scan: keep looking at the other releases for one that
+			// does match.
+			log.Println("Skipping release", rel.TagName, "-", err)
+			continue
 		}
 
 		// Note: any version with suffix is less than any version without suffix.
@@ -120,14 +133,15 @@ func findReleaseAndAsset(rels []*github.RepositoryRelease,
 			ver = v
 			asset = a
 			release = rel
+			rollout = ro
 		}
 	}
 
 	if release == nil {
-		return nil, nil, semver.Version{}, fmt.Errorf("could not find any release for %s and %s", runtime.GOOS, runtime.GOARCH)
+		return nil, nil, semver.Version{}, nil, fmt.Errorf("could not find any release for %s and %s", runtime.GOOS, runtime.GOARCH)
 	}
 
-	return release, asset, ver, nil
+	return release, asset, ver, rollout, nil
 }
 
 // DetectLatest tries to get the latest version of the repository on GitHub. 'slug' means 'owner/name' formatted string.
@@ -140,49 +154,56 @@ func (up *Updater) DetectLatest(owner, name string) (*Release, error) {
 	return up.DetectVersion(owner, name, "")
 }
 
-// DetectVersion tries to get the given version of the repository on Github.
+// DetectVersion tries to get the given version of the repository from its release source.
+// A release that is a draft/pre-release, has no asset for the running OS/arch, isn't
+// semver-tagged, or isn't yet rolled out to this install (see RolloutStrategy) is skipped
+// in favor of the next matching release, rather than failing the call outright. An error
+// is only returned once no release in the list matches at all. When version is given,
+// only the release tagged exactly version is considered, and it is not checked for
+// being a draft, a pre-release or rolled out.
 func (up *Updater) DetectVersion(owner, name, version string) (*Release, error) {
-	rels, res, err := up.api.Repositories.ListReleases(up.apiCtx, owner, name, nil)
+	rels, err := up.source.ListReleases(up.apiCtx, owner, name)
 	if err != nil {
-		log.Println("API returned an error response:", err)
-		if res != nil && res.StatusCode == 404 {
-			return nil, fmt.Errorf("repository or release not found: %w", err)
-		}
-
 		return nil, fmt.Errorf("failed to fetch releases: %w", err)
 	}
 
-	rel, asset, ver, err := findReleaseAndAsset(rels, version, up.filters)
+	strategy := up.rolloutStrategy
+	if strategy == nil {
+		strategy = DefaultRolloutStrategy{}
+	}
+
+	rel, asset, ver, rollout, err := findReleaseAndAsset(rels, version, up.filters, strategy, clientKey(up.clientID, owner, name))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find release and asset: %w", err)
 	}
 
-	url := asset.GetBrowserDownloadURL()
-	log.Println("Successfully fetched the latest release. tag:", rel.GetTagName(), ", name:", rel.GetName(), ", URL:", rel.GetURL(), ", Asset:", url)
+	url := asset.BrowserDownloadURL
+	log.Println("Successfully fetched the latest release. tag:", rel.TagName, ", name:", rel.Name, ", URL:", rel.HTMLURL, ", Asset:", url)
 
-	publishedAt := rel.GetPublishedAt().Time
 	release := &Release{
 		Version:           ver,
 		AssetURL:          url,
-		AssetByteSize:     asset.GetSize(),
-		AssetID:           asset.GetID(),
+		AssetByteSize:     asset.Size,
+		AssetID:           asset.ID,
 		ValidationAssetID: -1,
-		URL:               rel.GetHTMLURL(),
-		ReleaseNotes:      rel.GetBody(),
-		Name:              rel.GetName(),
-		PublishedAt:       &publishedAt,
+		URL:               rel.HTMLURL,
+		ReleaseNotes:      rel.Body,
+		Name:              rel.Name,
+		PublishedAt:       rel.PublishedAt,
 		RepoOwner:         owner,
 		RepoName:          name,
+		Patches:           findPatchAssets(rel, ver.String()),
+		Rollout:           rollout,
 	}
 
 	if up.validator != nil {
-		validationName := asset.GetName() + up.validator.Suffix()
+		validationName := asset.Name + up.validator.Suffix()
 		validationAsset, ok := findValidationAsset(rel, validationName)
 		if !ok {
 			return nil, fmt.Errorf("Failed finding validation file %q", validationName)
 		}
 
-		release.ValidationAssetID = validationAsset.GetID()
+		release.ValidationAssetID = validationAsset.ID
 	}
 
 	return release, nil
@@ -0,0 +1,27 @@
+/*
+Package selfupdate provides self-update mechanism to Go command line tools.
+
+Go does not provide the way to install/update the stable version of tools. By default, Go command line tools are updated
+
+- using `go get -u` (updating to HEAD)
+- using system's package manager (depending on the platform)
+- downloading executables from a release page manually
+
+By using this library, you will get 4th choice:
+
+- from your command line tool directly (and automatically)
+
+go-github-selfupdate detects the information of the latest release via a ReleaseSource
+and checks the current version. If newer version than itself is detected, it downloads
+the released binary (or a bsdiff patch, when one is published) and replaces itself.
+
+- Automatically detects the latest version of released binary
+- GitHubSource, GitLabSource and GiteaSource let it talk to GitHub, GitLab and Gitea (or
+  self-hosted instances of any of them); set Config.Source to use a non-GitHub backend
+- Retrieve the proper binary for the OS and arch where the binary is running
+- Update the binary with rollback support on failure
+- Tested on Linux, macOS and Windows
+- Many archive and compression formats are supported (zip, gzip, xzip, tar)
+- Maintainers can stage a release to a percentage of installs via Config.RolloutStrategy
+*/
+package selfupdate
@@ -0,0 +1,85 @@
+package selfupdate
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/gabstv/go-bsdiff/pkg/bspatch"
+	update "github.com/inconshreveable/go-update"
+)
+
+var rePatchAssetVersion = regexp.MustCompile(`_(\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.-]+)*)_to_`)
+
+// findPatchAssets scans a release's assets for delta patches named
+// "<binary>_<oldver>_to_<newver>_<os>_<arch>.bspatch" that upgrade straight
+// to this release (newVer), and returns them keyed by oldver so
+// Updater.UpdateCommand can look up a patch from the currently running
+// version.
+func findPatchAssets(rel *SourceRelease, newVer string) map[string]int64 {
+	suffix := fmt.Sprintf("_to_%s_%s_%s.bspatch", newVer, runtime.GOOS, runtime.GOARCH)
+
+	patches := make(map[string]int64)
+	for _, asset := range rel.Assets {
+		if !strings.HasSuffix(asset.Name, suffix) {
+			continue
+		}
+		match := rePatchAssetVersion.FindStringSubmatch(asset.Name)
+		if match == nil {
+			continue
+		}
+		patches[match[1]] = asset.ID
+	}
+	return patches
+}
+
+// updateViaPatch downloads the bsdiff patch identified by patchAssetID and
+// applies it to the currently running executable at cmdPath, instead of
+// downloading rel's full binary asset. The patched binary is validated the
+// same way UpdateTo would validate a full download before being handed to
+// go-update.
+func (up *Updater) updateViaPatch(rel *Release, patchAssetID int64, cmdPath string) error {
+	patchSrc, err := up.source.DownloadAsset(up.apiCtx, rel.RepoOwner, rel.RepoName, patchAssetID)
+	if err != nil {
+		return fmt.Errorf("failed to download patch asset(ID: %d): %w", patchAssetID, err)
+	}
+	defer patchSrc.Close()
+
+	patch, err := ioutil.ReadAll(patchSrc)
+	if err != nil {
+		return fmt.Errorf("failed reading patch asset body: %w", err)
+	}
+
+	old, err := ioutil.ReadFile(cmdPath)
+	if err != nil {
+		return fmt.Errorf("failed reading current executable %q: %w", cmdPath, err)
+	}
+
+	patched, err := bspatch.Bytes(old, patch)
+	if err != nil {
+		return fmt.Errorf("failed applying bsdiff patch: %w", err)
+	}
+
+	if up.validator != nil {
+		validationSrc, err := up.source.DownloadAsset(up.apiCtx, rel.RepoOwner, rel.RepoName, rel.ValidationAssetID)
+		if err != nil {
+			return fmt.Errorf("failed to download validation asset(ID: %d) for repository '%s/%s': %w", rel.ValidationAssetID, rel.RepoOwner, rel.RepoName, err)
+		}
+		defer validationSrc.Close()
+
+		validationData, err := ioutil.ReadAll(validationSrc)
+		if err != nil {
+			return fmt.Errorf("failed reading validation asset body: %w", err)
+		}
+
+		if err := up.validator.Validate(patched, validationData); err != nil {
+			return fmt.Errorf("patched binary failed validation: %w", err)
+		}
+	}
+
+	log.Println("Applying bsdiff patch to", cmdPath)
+	return update.Apply(bytes.NewReader(patched), update.Options{TargetPath: cmdPath})
+}
@@ -0,0 +1,30 @@
+package selfupdate
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestFindPatchAssets(t *testing.T) {
+	suffix := fmt.Sprintf("%s_%s", runtime.GOOS, runtime.GOARCH)
+	rel := &SourceRelease{
+		Assets: []SourceAsset{
+			{ID: 1, Name: "foo_" + suffix + ".tar.gz"},
+			{ID: 2, Name: "foo_1.2.2_to_1.2.3_" + suffix + ".bspatch"},
+			{ID: 3, Name: "foo_1.2.1_to_1.2.3_" + suffix + ".bspatch"},
+			{ID: 4, Name: "foo_1.2.2_to_1.2.4_" + suffix + ".bspatch"},
+		},
+	}
+
+	patches := findPatchAssets(rel, "1.2.3")
+	if len(patches) != 2 {
+		t.Fatalf("expected 2 patches targeting 1.2.3, got %d: %v", len(patches), patches)
+	}
+	if patches["1.2.2"] != 2 {
+		t.Errorf("expected patch from 1.2.2 to be asset 2, got %d", patches["1.2.2"])
+	}
+	if patches["1.2.1"] != 3 {
+		t.Errorf("expected patch from 1.2.1 to be asset 3, got %d", patches["1.2.1"])
+	}
+}
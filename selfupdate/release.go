@@ -0,0 +1,42 @@
+package selfupdate
+
+import (
+	"time"
+
+	"github.com/blang/semver"
+)
+
+// Release represents a release asset for current OS and arch.
+type Release struct {
+	// Version is the version of the release
+	Version semver.Version
+	// AssetURL is a URL to the uploaded file for the release
+	AssetURL string
+	// AssetByteSize represents the size of asset in bytes
+	AssetByteSize int
+	// AssetID is the ID of the asset on the release source (GitHub, GitLab, Gitea, ...)
+	AssetID int64
+	// ValidationAssetID is the ID of additional validaton asset on the release source
+	ValidationAssetID int64
+	// URL is a URL to release page for browsing
+	URL string
+	// ReleaseNotes is a release notes of the release
+	ReleaseNotes string
+	// Name represents a name of the release
+	Name string
+	// PublishedAt is the time when the release was published
+	PublishedAt *time.Time
+	// RepoOwner is the owner of the repository of the release
+	RepoOwner string
+	// RepoName is the name of the repository of the release
+	RepoName string
+	// Patches maps a prior released version string to the ID of a bsdiff
+	// delta-patch asset that upgrades straight from that version to this
+	// release, when the maintainer published one. It is empty when no
+	// patch asset was found.
+	Patches map[string]int64
+	// Rollout is the ```selfupdate rollout directive parsed from the
+	// release's body, or nil if it has none. Callers can inspect or
+	// override the library's rollout decision using this.
+	Rollout *RolloutInfo
+}
@@ -0,0 +1,87 @@
+package selfupdate
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestParseRolloutInfo(t *testing.T) {
+	body := "Release notes.\n\n```selfupdate\nrollout: 25%\ncohorts: [beta]\nmin_version: 1.4.0\n```\n"
+
+	rollout := parseRolloutInfo(body)
+	if rollout == nil {
+		t.Fatal("expected a rollout directive to be parsed")
+	}
+	if rollout.Percent != 25 {
+		t.Errorf("wrong percent, got %v", rollout.Percent)
+	}
+	if len(rollout.Cohorts) != 1 || rollout.Cohorts[0] != "beta" {
+		t.Errorf("wrong cohorts, got %v", rollout.Cohorts)
+	}
+	if rollout.MinVersion != "1.4.0" {
+		t.Errorf("wrong min version, got %q", rollout.MinVersion)
+	}
+}
+
+func TestParseRolloutInfoNoBlock(t *testing.T) {
+	if rollout := parseRolloutInfo("Just a regular release body."); rollout != nil {
+		t.Errorf("expected no rollout directive, got %+v", rollout)
+	}
+}
+
+func TestDefaultRolloutStrategyEligible(t *testing.T) {
+	always := DefaultRolloutStrategy{}
+
+	if !always.Eligible(nil, "any-client") {
+		t.Error("a release without a rollout directive should always be eligible")
+	}
+	if !always.Eligible(&RolloutInfo{Percent: 100}, "any-client") {
+		t.Error("a 100% rollout should always be eligible")
+	}
+	if always.Eligible(&RolloutInfo{Percent: 0}, "any-client") {
+		t.Error("a 0% rollout should never be eligible")
+	}
+}
+
+func TestClientKeyUsesClientIDWhenSet(t *testing.T) {
+	if got := clientKey("fixed-id", "owner", "name"); got != "fixed-id" {
+		t.Errorf("expected configured ClientID to be used verbatim, got %q", got)
+	}
+}
+
+// alwaysIneligible rejects every rolled-out release, simulating a client
+// whose hash bucket never falls under the advertised percentage.
+type alwaysIneligible struct{}
+
+func (alwaysIneligible) Eligible(rollout *RolloutInfo, clientKey string) bool {
+	return rollout == nil
+}
+
+func TestFindReleaseAndAssetFallsBackPastIneligibleRelease(t *testing.T) {
+	suffix := fmt.Sprintf("_%s_%s", runtime.GOOS, runtime.GOARCH)
+	newer := &SourceRelease{
+		TagName: "v2.0.0",
+		Body:    "```selfupdate\nrollout: 25%\n```",
+		Assets:  []SourceAsset{{ID: 1, Name: "foo" + suffix}},
+	}
+	older := &SourceRelease{
+		TagName: "v1.9.0",
+		Assets:  []SourceAsset{{ID: 2, Name: "foo" + suffix}},
+	}
+
+	release, asset, ver, _, err := findReleaseAndAsset(
+		[]*SourceRelease{newer, older}, "", nil, alwaysIneligible{}, "unlucky-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release != older {
+		t.Errorf("expected to fall back to the older, eligible release, got %q", release.TagName)
+	}
+	if asset.ID != 2 {
+		t.Errorf("expected the older release's asset, got ID %d", asset.ID)
+	}
+	if ver.String() != "1.9.0" {
+		t.Errorf("expected the older release's version, got %s", ver.String())
+	}
+}
@@ -0,0 +1,69 @@
+package selfupdate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SourceRelease is a single release as reported by a ReleaseSource, normalized
+// across backends so that detect.go does not need to know whether it talked
+// to GitHub, GitLab or Gitea.
+type SourceRelease struct {
+	TagName     string
+	Name        string
+	Body        string
+	Draft       bool
+	Prerelease  bool
+	PublishedAt *time.Time
+	HTMLURL     string
+	Assets      []SourceAsset
+}
+
+// SourceAsset is a single downloadable file attached to a SourceRelease.
+type SourceAsset struct {
+	ID                 int64
+	Name               string
+	Size               int
+	BrowserDownloadURL string
+}
+
+// ReleaseSource abstracts the release-hosting backend an Updater talks to in
+// order to list releases and download their assets. GitHubSource is the
+// default; GitLabSource and GiteaSource let UpdateSelf/UpdateTo work against
+// self-hosted installations behind the same API.
+type ReleaseSource interface {
+	// ListReleases lists the releases (including drafts and pre-releases)
+	// published for owner/name. GitHubSource, GitLabSource and GiteaSource
+	// make a single unpaged request and return only the API's first page.
+	ListReleases(ctx context.Context, owner, name string) ([]*SourceRelease, error)
+	// DownloadAsset downloads the asset with the given ID from owner/name.
+	DownloadAsset(ctx context.Context, owner, name string, assetID int64) (io.ReadCloser, error)
+}
+
+// downloadFromURL performs a plain HTTP GET against assetURL, adding an
+// optional auth header. It is shared by the non-GitHub sources, which
+// identify assets by a direct URL rather than an API download endpoint.
+func downloadFromURL(ctx context.Context, assetURL, headerName, headerValue string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, assetURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create HTTP request to %s: %s", assetURL, err)
+	}
+	req.Header.Add("Accept", "application/octet-stream")
+	if headerName != "" {
+		req.Header.Set(headerName, headerValue)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to download a release file from %s: %s", assetURL, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("Failed to download a release file from %s: Not successful status %d", assetURL, res.StatusCode)
+	}
+
+	return res.Body, nil
+}
@@ -0,0 +1,91 @@
+package selfupdate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGiteaSourceListReleases(t *testing.T) {
+	var gotPath, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`[{
+			"tag_name": "v1.2.3",
+			"name": "v1.2.3",
+			"body": "release notes",
+			"draft": false,
+			"prerelease": true,
+			"created_at": "2021-01-02T03:04:05Z",
+			"html_url": "https://example.com/owner/name/releases/v1.2.3",
+			"assets": [{"id": 42, "name": "foo_linux_amd64.tar.gz", "size": 1024, "browser_download_url": "https://example.com/foo_linux_amd64.tar.gz"}]
+		}]`))
+	}))
+	defer srv.Close()
+
+	s := &GiteaSource{BaseURL: srv.URL, Token: "hogehoge"}
+	rels, err := s.ListReleases(context.Background(), "owner", "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "/api/v1/repos/owner/name/releases"; gotPath != want {
+		t.Errorf("wrong path requested, got %s, want %s", gotPath, want)
+	}
+	if want := "token hogehoge"; gotAuth != want {
+		t.Errorf("wrong Authorization header sent, got %q, want %q", gotAuth, want)
+	}
+
+	if len(rels) != 1 {
+		t.Fatalf("expected 1 release, got %d", len(rels))
+	}
+	rel := rels[0]
+	if rel.TagName != "v1.2.3" {
+		t.Errorf("wrong tag name, got %s", rel.TagName)
+	}
+	if !rel.Prerelease {
+		t.Error("expected release to be marked as a prerelease")
+	}
+	if len(rel.Assets) != 1 || rel.Assets[0].ID != 42 || rel.Assets[0].Size != 1024 {
+		t.Errorf("wrong assets, got %+v", rel.Assets)
+	}
+}
+
+func TestGiteaSourceDownloadAsset(t *testing.T) {
+	var assetReq *http.Request
+	asset := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assetReq = r
+		w.Write([]byte("binary-content"))
+	}))
+	defer asset.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"tag_name": "v1.2.3", "assets": [{"id": 42, "name": "foo", "browser_download_url": "` + asset.URL + `/foo"}]}]`))
+	}))
+	defer srv.Close()
+
+	s := &GiteaSource{BaseURL: srv.URL, Token: "hogehoge"}
+	rc, err := s.DownloadAsset(context.Background(), "owner", "name", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+
+	if want := "token hogehoge"; assetReq.Header.Get("Authorization") != want {
+		t.Errorf("wrong Authorization header sent to asset URL, got %q, want %q", assetReq.Header.Get("Authorization"), want)
+	}
+}
+
+func TestGiteaSourceDownloadAssetNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	s := &GiteaSource{BaseURL: srv.URL}
+	if _, err := s.DownloadAsset(context.Background(), "owner", "name", 42); err == nil {
+		t.Fatal("expected an error for a missing asset")
+	}
+}
@@ -0,0 +1,71 @@
+package selfupdate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// GitHubSource is the default ReleaseSource. It talks to the GitHub Releases
+// API via api, which is also reachable through Updater.api for GitHub
+// Enterprise configuration.
+type GitHubSource struct {
+	api    *github.Client
+	apiCtx context.Context
+}
+
+// ListReleases implements ReleaseSource.
+func (s *GitHubSource) ListReleases(ctx context.Context, owner, name string) ([]*SourceRelease, error) {
+	rels, res, err := s.api.Repositories.ListReleases(ctx, owner, name, nil)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("repository or release not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	out := make([]*SourceRelease, 0, len(rels))
+	for _, rel := range rels {
+		publishedAt := rel.GetPublishedAt().Time
+		assets := make([]SourceAsset, 0, len(rel.Assets))
+		for _, a := range rel.Assets {
+			assets = append(assets, SourceAsset{
+				ID:                 a.GetID(),
+				Name:               a.GetName(),
+				Size:               a.GetSize(),
+				BrowserDownloadURL: a.GetBrowserDownloadURL(),
+			})
+		}
+		out = append(out, &SourceRelease{
+			TagName:     rel.GetTagName(),
+			Name:        rel.GetName(),
+			Body:        rel.GetBody(),
+			Draft:       rel.GetDraft(),
+			Prerelease:  rel.GetPrerelease(),
+			PublishedAt: &publishedAt,
+			HTMLURL:     rel.GetHTMLURL(),
+			Assets:      assets,
+		})
+	}
+	return out, nil
+}
+
+// DownloadAsset implements ReleaseSource. It downloads the asset via the
+// GitHub Releases API, which works for private repositories too, falling
+// back to downloading directly from the redirect URL GitHub sometimes
+// returns instead of the asset body.
+func (s *GitHubSource) DownloadAsset(ctx context.Context, owner, name string, assetID int64) (io.ReadCloser, error) {
+	var client http.Client
+	src, redirectURL, err := s.api.Repositories.DownloadReleaseAsset(ctx, owner, name, assetID, &client)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to call GitHub Releases API for getting an asset(ID: %d) for repository '%s/%s': %s", assetID, owner, name, err)
+	}
+	if redirectURL != "" {
+		log.Println("Redirect URL was returned while trying to download a release asset from GitHub API. Falling back to downloading from asset URL directly:", redirectURL)
+		return downloadFromURL(ctx, redirectURL, "", "")
+	}
+	return src, nil
+}
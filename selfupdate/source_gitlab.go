@@ -0,0 +1,134 @@
+package selfupdate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GitLabSource is a ReleaseSource backed by the GitLab Releases API. It works
+// against both gitlab.com and self-hosted GitLab instances, fetching assets
+// published as Generic Packages or plain release links.
+type GitLabSource struct {
+	// BaseURL is the GitLab instance to talk to, e.g. "https://gitlab.example.com".
+	// Defaults to "https://gitlab.com" when empty.
+	BaseURL string
+	// PrivateToken authenticates requests via the "PRIVATE-TOKEN" header.
+	// Required to read releases on private projects.
+	PrivateToken string
+	// HTTPClient is used to make API requests. http.DefaultClient is used when nil.
+	// Asset downloads always go through http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type gitlabReleaseLink struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type gitlabRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ReleasedAt  time.Time `json:"released_at"`
+	Assets      struct {
+		Links []gitlabReleaseLink `json:"links"`
+	} `json:"assets"`
+}
+
+func (s *GitLabSource) baseURL() string {
+	if s.BaseURL == "" {
+		return "https://gitlab.com"
+	}
+	return strings.TrimSuffix(s.BaseURL, "/")
+}
+
+func (s *GitLabSource) httpClient() *http.Client {
+	if s.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return s.HTTPClient
+}
+
+func (s *GitLabSource) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL()+path, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to create HTTP request to %s: %s", path, err)
+	}
+	if s.PrivateToken != "" {
+		req.Header.Set("PRIVATE-TOKEN", s.PrivateToken)
+	}
+
+	res, err := s.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call GitLab API %s: %w", path, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("repository or release not found: %s", path)
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitLab API %s returned status %d", path, res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// ListReleases implements ReleaseSource.
+func (s *GitLabSource) ListReleases(ctx context.Context, owner, name string) ([]*SourceRelease, error) {
+	project := url.PathEscape(owner + "/" + name)
+
+	var rels []gitlabRelease
+	if err := s.get(ctx, fmt.Sprintf("/api/v4/projects/%s/releases", project), &rels); err != nil {
+		return nil, err
+	}
+
+	out := make([]*SourceRelease, 0, len(rels))
+	for _, rel := range rels {
+		releasedAt := rel.ReleasedAt
+		assets := make([]SourceAsset, 0, len(rel.Assets.Links))
+		for _, link := range rel.Assets.Links {
+			assets = append(assets, SourceAsset{
+				ID:                 link.ID,
+				Name:               link.Name,
+				BrowserDownloadURL: link.URL,
+			})
+		}
+		out = append(out, &SourceRelease{
+			TagName:     rel.TagName,
+			Name:        rel.Name,
+			Body:        rel.Description,
+			PublishedAt: &releasedAt,
+			HTMLURL:     fmt.Sprintf("%s/%s/-/releases/%s", s.baseURL(), owner+"/"+name, rel.TagName),
+			Assets:      assets,
+		})
+	}
+	return out, nil
+}
+
+// DownloadAsset implements ReleaseSource. GitLab release links are plain
+// URLs rather than an API download endpoint, so the release list is searched
+// again for the asset with a matching ID and its link is downloaded directly.
+func (s *GitLabSource) DownloadAsset(ctx context.Context, owner, name string, assetID int64) (io.ReadCloser, error) {
+	rels, err := s.ListReleases(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rel := range rels {
+		for _, asset := range rel.Assets {
+			if asset.ID == assetID {
+				return downloadFromURL(ctx, asset.BrowserDownloadURL, "PRIVATE-TOKEN", s.PrivateToken)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("asset %d not found in project %s/%s", assetID, owner, name)
+}
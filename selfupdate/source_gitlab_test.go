@@ -0,0 +1,91 @@
+package selfupdate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitLabSourceListReleases(t *testing.T) {
+	var gotPath, gotToken string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotToken = r.Header.Get("PRIVATE-TOKEN")
+		w.Write([]byte(`[{
+			"tag_name": "v1.2.3",
+			"name": "v1.2.3",
+			"description": "release notes",
+			"released_at": "2021-01-02T03:04:05Z",
+			"assets": {"links": [{"id": 42, "name": "foo_linux_amd64.tar.gz", "url": "https://example.com/foo_linux_amd64.tar.gz"}]}
+		}]`))
+	}))
+	defer srv.Close()
+
+	s := &GitLabSource{BaseURL: srv.URL, PrivateToken: "glpat-hogehoge"}
+	rels, err := s.ListReleases(context.Background(), "owner", "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "/api/v4/projects/owner%2Fname/releases"; gotPath != want {
+		t.Errorf("wrong path requested, got %s, want %s", gotPath, want)
+	}
+	if want := "glpat-hogehoge"; gotToken != want {
+		t.Errorf("wrong PRIVATE-TOKEN sent, got %q, want %q", gotToken, want)
+	}
+
+	if len(rels) != 1 {
+		t.Fatalf("expected 1 release, got %d", len(rels))
+	}
+	rel := rels[0]
+	if rel.TagName != "v1.2.3" {
+		t.Errorf("wrong tag name, got %s", rel.TagName)
+	}
+	if rel.Body != "release notes" {
+		t.Errorf("wrong body, got %s", rel.Body)
+	}
+	if len(rel.Assets) != 1 || rel.Assets[0].ID != 42 || rel.Assets[0].Name != "foo_linux_amd64.tar.gz" {
+		t.Errorf("wrong assets, got %+v", rel.Assets)
+	}
+	if rel.Assets[0].BrowserDownloadURL != "https://example.com/foo_linux_amd64.tar.gz" {
+		t.Errorf("wrong download URL, got %s", rel.Assets[0].BrowserDownloadURL)
+	}
+}
+
+func TestGitLabSourceDownloadAsset(t *testing.T) {
+	var assetReq *http.Request
+	asset := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assetReq = r
+		w.Write([]byte("binary-content"))
+	}))
+	defer asset.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"tag_name": "v1.2.3", "assets": {"links": [{"id": 42, "name": "foo", "url": "` + asset.URL + `/foo"}]}}]`))
+	}))
+	defer srv.Close()
+
+	s := &GitLabSource{BaseURL: srv.URL, PrivateToken: "glpat-hogehoge"}
+	rc, err := s.DownloadAsset(context.Background(), "owner", "name", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+
+	if assetReq.Header.Get("PRIVATE-TOKEN") != "glpat-hogehoge" {
+		t.Errorf("wrong PRIVATE-TOKEN sent to asset URL, got %q", assetReq.Header.Get("PRIVATE-TOKEN"))
+	}
+}
+
+func TestGitLabSourceDownloadAssetNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	s := &GitLabSource{BaseURL: srv.URL}
+	if _, err := s.DownloadAsset(context.Background(), "owner", "name", 42); err == nil {
+		t.Fatal("expected an error for a missing asset")
+	}
+}
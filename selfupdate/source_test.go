@@ -0,0 +1,61 @@
+package selfupdate
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestFindReleaseAndAssetUsesSourceRelease(t *testing.T) {
+	rel := &SourceRelease{
+		TagName: "v1.2.3",
+		Assets: []SourceAsset{
+			{ID: 1, Name: fmt.Sprintf("foo_%s_%s", runtime.GOOS, runtime.GOARCH)},
+		},
+	}
+
+	release, asset, ver, _, err := findReleaseAndAsset([]*SourceRelease{rel}, "", nil, DefaultRolloutStrategy{}, "test-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release != rel {
+		t.Error("wrong release was returned")
+	}
+	if asset.ID != 1 {
+		t.Error("wrong asset was returned, got ID", asset.ID)
+	}
+	if ver.String() != "1.2.3" {
+		t.Error("wrong version was parsed, got", ver.String())
+	}
+}
+
+// TestFindReleaseAndAssetSkipsReleaseWithNoMatchingAsset pins the scan's
+// behavior: a release with no asset for the current OS/arch is skipped in
+// favor of an older release that does have one, rather than failing the
+// whole scan.
+func TestFindReleaseAndAssetSkipsReleaseWithNoMatchingAsset(t *testing.T) {
+	newer := &SourceRelease{
+		TagName: "v2.0.0",
+		Assets:  []SourceAsset{{ID: 1, Name: "foo_some_other_os_arch"}},
+	}
+	suffix := fmt.Sprintf("_%s_%s", runtime.GOOS, runtime.GOARCH)
+	older := &SourceRelease{
+		TagName: "v1.9.0",
+		Assets:  []SourceAsset{{ID: 2, Name: "foo" + suffix}},
+	}
+
+	release, asset, ver, _, err := findReleaseAndAsset(
+		[]*SourceRelease{newer, older}, "", nil, DefaultRolloutStrategy{}, "test-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release != older {
+		t.Errorf("expected to fall back to the older release with a matching asset, got %q", release.TagName)
+	}
+	if asset.ID != 2 {
+		t.Errorf("expected the older release's asset, got ID %d", asset.ID)
+	}
+	if ver.String() != "1.9.0" {
+		t.Errorf("expected the older release's version, got %s", ver.String())
+	}
+}
@@ -0,0 +1,151 @@
+package selfupdate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+	update "github.com/inconshreveable/go-update"
+)
+
+func uncompressAndUpdate(src io.Reader, assetURL, cmdPath string) error {
+	_, cmd := filepath.Split(cmdPath)
+	asset, err := UncompressCommand(src, assetURL, cmd)
+	if err != nil {
+		return err
+	}
+
+	log.Println("Will update", cmdPath, "to the latest downloaded from", assetURL)
+	return update.Apply(asset, update.Options{
+		TargetPath: cmdPath,
+	})
+}
+
+func (up *Updater) downloadDirectlyFromURL(assetURL string) (io.ReadCloser, error) {
+	return downloadFromURL(up.apiCtx, assetURL, "", "")
+}
+
+// UpdateTo downloads an executable via the Updater's ReleaseSource and
+// replaces current binary with the downloaded one. It downloads a release
+// asset through the source so this function is available for update releases
+// on a private repository.
+func (up *Updater) UpdateTo(rel *Release, cmdPath string) error {
+	src, err := up.source.DownloadAsset(up.apiCtx, rel.RepoOwner, rel.RepoName, rel.AssetID)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	data, err := ioutil.ReadAll(src)
+	if err != nil {
+		return fmt.Errorf("Failed reading asset body: %v", err)
+	}
+
+	if up.validator == nil {
+		return uncompressAndUpdate(bytes.NewReader(data), rel.AssetURL, cmdPath)
+	}
+
+	validationSrc, err := up.source.DownloadAsset(up.apiCtx, rel.RepoOwner, rel.RepoName, rel.ValidationAssetID)
+	if err != nil {
+		return fmt.Errorf("Failed to download validation asset(ID: %d) for repository '%s/%s': %s", rel.ValidationAssetID, rel.RepoOwner, rel.RepoName, err)
+	}
+
+	defer validationSrc.Close()
+
+	validationData, err := ioutil.ReadAll(validationSrc)
+	if err != nil {
+		return fmt.Errorf("Failed reading validation asset body: %v", err)
+	}
+
+	if err := up.validator.Validate(data, validationData); err != nil {
+		return fmt.Errorf("Failed validating asset content: %v", err)
+	}
+
+	return uncompressAndUpdate(bytes.NewReader(data), rel.AssetURL, cmdPath)
+}
+
+// UpdateCommand updates a given command binary to the latest version.
+func (up *Updater) UpdateCommand(cmdPath string, current semver.Version, owner, name string) (*Release, error) {
+	if runtime.GOOS == "windows" && !strings.HasSuffix(cmdPath, ".exe") {
+		// Ensure to add '.exe' to given path on Windows
+		cmdPath = cmdPath + ".exe"
+	}
+
+	stat, err := os.Lstat(cmdPath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to stat '%s'. File may not exist: %s", cmdPath, err)
+	}
+	if stat.Mode()&os.ModeSymlink != 0 {
+		p, err := filepath.EvalSymlinks(cmdPath)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to resolve symlink '%s' for executable: %s", cmdPath, err)
+		}
+		cmdPath = p
+	}
+
+	rel, err := up.DetectLatest(owner, name)
+	if err != nil {
+		return nil, err
+	}
+	if current.Equals(rel.Version) {
+		log.Println("Current version", current, "is the latest. Update is not needed")
+		return rel, nil
+	}
+
+	if patchAssetID, ok := rel.Patches[current.String()]; ok {
+		log.Println("Found delta patch from", current, "to", rel.Version, "- applying it instead of the full asset")
+		if err := up.updateViaPatch(rel, patchAssetID, cmdPath); err == nil {
+			return rel, nil
+		} else {
+			log.Println("Applying delta patch failed, falling back to the full asset download:", err)
+		}
+	}
+
+	log.Println("Will update", cmdPath, "to the latest version", rel.Version)
+	if err := up.UpdateTo(rel, cmdPath); err != nil {
+		return nil, err
+	}
+	return rel, nil
+}
+
+// UpdateSelf updates the running executable itself to the latest version.
+// 'owner' and 'name' represent the 'owner/name' repository on GitHub and 'current' means the current version.
+func (up *Updater) UpdateSelf(current semver.Version, owner, name string) (*Release, error) {
+	cmdPath, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return up.UpdateCommand(cmdPath, current, owner, name)
+}
+
+// UpdateTo downloads an executable from assetURL and replace the current binary with the downloaded one.
+// This function is low-level API to update the binary. Because it does not use GitHub API and downloads asset directly from the URL via HTTP,
+// this function is not available to update a release for private repositories.
+// cmdPath is a file path to command executable.
+func UpdateTo(assetURL, cmdPath string) error {
+	up := DefaultUpdater()
+	src, err := up.downloadDirectlyFromURL(assetURL)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return uncompressAndUpdate(src, assetURL, cmdPath)
+}
+
+// UpdateCommand updates a given command binary to the latest version.
+// This function is a shortcut version of updater.UpdateCommand.
+func UpdateCommand(cmdPath string, current semver.Version, owner, name string) (*Release, error) {
+	return DefaultUpdater().UpdateCommand(cmdPath, current, owner, name)
+}
+
+// UpdateSelf updates the running executable itself to the latest version.
+// This function is a shortcut version of updater.UpdateSelf.
+func UpdateSelf(current semver.Version, owner, name string) (*Release, error) {
+	return DefaultUpdater().UpdateSelf(current, owner, name)
+}
@@ -68,6 +68,22 @@ func TestGitHubEnterpriseClient(t *testing.T) {
 	}
 }
 
+func TestGitHubEnterpriseClientDocumentedBaseURL(t *testing.T) {
+	baseURL := "https://github.company.com/api/v3/"
+	up, err := NewUpdater(Config{APIToken: "hogehoge", EnterpriseBaseURL: baseURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if up.api.BaseURL.String() != baseURL {
+		t.Error("Base URL was set to", up.api.BaseURL, ", want", baseURL)
+	}
+
+	if want := "https://github.company.com/api/v3/api/uploads/"; up.api.UploadURL.String() != want {
+		t.Error("Upload URL was set to", up.api.UploadURL, ", want", want)
+	}
+}
+
 func TestGitHubEnterpriseClientInvalidURL(t *testing.T) {
 	_, err := NewUpdater(Config{APIToken: "hogehoge", EnterpriseBaseURL: ":this is not a URL"})
 	if err == nil {